@@ -0,0 +1,147 @@
+package txmgr
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	bolt "go.etcd.io/bbolt"
+)
+
+// attemptsBucket is the sole bbolt bucket used by BoltStore, holding one
+// entry per attempt keyed by its tx hash.
+var attemptsBucket = []byte("attempts")
+
+// boltAttempt is the on-disk representation of a StoredAttempt. big.Ints are
+// marshaled through their string form since encoding/json has no native
+// support for them.
+type boltAttempt struct {
+	Nonce  uint64
+	Hash   common.Hash
+	RawTx  []byte
+	TipCap string
+	FeeCap string
+	State  TxState
+}
+
+// BoltStore is a Store backed by a bbolt (an embedded, file-based key/value
+// store) database, suitable for a single long-running daemon process.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltStore at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(attemptsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Close closes the underlying bbolt database.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// SaveAttempt implements Store.
+func (s *BoltStore) SaveAttempt(
+	nonce uint64, hash common.Hash, rawTx []byte, tipCap, feeCap *big.Int,
+	state TxState,
+) error {
+
+	entry := boltAttempt{
+		Nonce:  nonce,
+		Hash:   hash,
+		RawTx:  rawTx,
+		TipCap: tipCap.String(),
+		FeeCap: feeCap.String(),
+		State:  state,
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(attemptsBucket).Put(hash.Bytes(), data)
+	})
+}
+
+// LoadInFlight implements Store.
+func (s *BoltStore) LoadInFlight() ([]StoredAttempt, error) {
+	var attempts []StoredAttempt
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(attemptsBucket).ForEach(func(_, data []byte) error {
+			var entry boltAttempt
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return err
+			}
+			switch entry.State {
+			case TxStateConfirmed, TxStateFatalError, TxStateSuperseded:
+				return nil
+			}
+
+			tipCap, ok := new(big.Int).SetString(entry.TipCap, 10)
+			if !ok {
+				tipCap = new(big.Int)
+			}
+			feeCap, ok := new(big.Int).SetString(entry.FeeCap, 10)
+			if !ok {
+				feeCap = new(big.Int)
+			}
+
+			attempts = append(attempts, StoredAttempt{
+				Nonce:  entry.Nonce,
+				Hash:   entry.Hash,
+				RawTx:  entry.RawTx,
+				TipCap: tipCap,
+				FeeCap: feeCap,
+				State:  entry.State,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// MarkConfirmed implements Store.
+func (s *BoltStore) MarkConfirmed(hash common.Hash) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(attemptsBucket)
+
+		data := bucket.Get(hash.Bytes())
+		if data == nil {
+			return nil
+		}
+
+		var entry boltAttempt
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return err
+		}
+		entry.State = TxStateConfirmed
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(hash.Bytes(), data)
+	})
+}
@@ -0,0 +1,130 @@
+package txmgr
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func newTestBoltStore(t *testing.T) *BoltStore {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "txmgr.db")
+	store, err := NewBoltStore(path)
+	if err != nil {
+		t.Fatalf("NewBoltStore returned error: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := store.Close(); err != nil {
+			t.Errorf("Close returned error: %v", err)
+		}
+	})
+
+	return store
+}
+
+// TestBoltStoreSaveAndLoadRoundTrip verifies that an attempt's nonce, hash,
+// raw tx bytes, and big.Int tip/fee caps all survive a round trip through
+// bbolt unchanged.
+func TestBoltStoreSaveAndLoadRoundTrip(t *testing.T) {
+	store := newTestBoltStore(t)
+
+	hash := common.HexToHash("0xaaa")
+	rawTx := []byte{0x01, 0x02, 0x03}
+	tipCap := big.NewInt(1_500_000_000)
+	feeCap := big.NewInt(30_000_000_000)
+
+	err := store.SaveAttempt(7, hash, rawTx, tipCap, feeCap, TxStateInProgress)
+	if err != nil {
+		t.Fatalf("SaveAttempt returned error: %v", err)
+	}
+
+	attempts, err := store.LoadInFlight()
+	if err != nil {
+		t.Fatalf("LoadInFlight returned error: %v", err)
+	}
+	if len(attempts) != 1 {
+		t.Fatalf("LoadInFlight returned %d attempts, want 1", len(attempts))
+	}
+
+	got := attempts[0]
+	if got.Nonce != 7 {
+		t.Errorf("Nonce = %d, want 7", got.Nonce)
+	}
+	if got.Hash != hash {
+		t.Errorf("Hash = %v, want %v", got.Hash, hash)
+	}
+	if string(got.RawTx) != string(rawTx) {
+		t.Errorf("RawTx = %v, want %v", got.RawTx, rawTx)
+	}
+	if got.TipCap.Cmp(tipCap) != 0 {
+		t.Errorf("TipCap = %v, want %v", got.TipCap, tipCap)
+	}
+	if got.FeeCap.Cmp(feeCap) != 0 {
+		t.Errorf("FeeCap = %v, want %v", got.FeeCap, feeCap)
+	}
+	if got.State != TxStateInProgress {
+		t.Errorf("State = %v, want %v", got.State, TxStateInProgress)
+	}
+}
+
+// TestBoltStoreMarkConfirmedExcludesFromLoadInFlight verifies that a
+// confirmed attempt no longer appears in LoadInFlight.
+func TestBoltStoreMarkConfirmedExcludesFromLoadInFlight(t *testing.T) {
+	store := newTestBoltStore(t)
+
+	hash := common.HexToHash("0xbbb")
+	err := store.SaveAttempt(1, hash, nil, big.NewInt(1), big.NewInt(1), TxStateInProgress)
+	if err != nil {
+		t.Fatalf("SaveAttempt returned error: %v", err)
+	}
+	if err := store.MarkConfirmed(hash); err != nil {
+		t.Fatalf("MarkConfirmed returned error: %v", err)
+	}
+
+	attempts, err := store.LoadInFlight()
+	if err != nil {
+		t.Fatalf("LoadInFlight returned error: %v", err)
+	}
+	if len(attempts) != 0 {
+		t.Errorf("LoadInFlight returned %d attempts, want 0 after confirmation", len(attempts))
+	}
+}
+
+// TestBoltStoreSupersededExcludesFromLoadInFlight verifies that once a later
+// attempt at the same nonce supersedes an earlier one, LoadInFlight returns
+// only the latest hash rather than leaving the superseded one stuck in
+// TxStateInProgress forever.
+func TestBoltStoreSupersededExcludesFromLoadInFlight(t *testing.T) {
+	store := newTestBoltStore(t)
+
+	nonce := uint64(3)
+	firstHash := common.HexToHash("0xccc")
+	secondHash := common.HexToHash("0xddd")
+
+	err := store.SaveAttempt(nonce, firstHash, nil, big.NewInt(1), big.NewInt(1), TxStateInProgress)
+	if err != nil {
+		t.Fatalf("SaveAttempt (first) returned error: %v", err)
+	}
+	err = store.SaveAttempt(nonce, secondHash, nil, big.NewInt(2), big.NewInt(2), TxStateInProgress)
+	if err != nil {
+		t.Fatalf("SaveAttempt (second) returned error: %v", err)
+	}
+	err = store.SaveAttempt(nonce, firstHash, nil, big.NewInt(1), big.NewInt(1), TxStateSuperseded)
+	if err != nil {
+		t.Fatalf("SaveAttempt (supersede) returned error: %v", err)
+	}
+
+	attempts, err := store.LoadInFlight()
+	if err != nil {
+		t.Fatalf("LoadInFlight returned error: %v", err)
+	}
+	if len(attempts) != 1 {
+		t.Fatalf("LoadInFlight returned %d attempts, want 1 (only the latest hash)", len(attempts))
+	}
+	if attempts[0].Hash != secondHash {
+		t.Errorf("LoadInFlight returned hash %v, want %v", attempts[0].Hash, secondHash)
+	}
+}
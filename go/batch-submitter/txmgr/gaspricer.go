@@ -0,0 +1,120 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// GasPricer computes the next value to use for a single fee dimension (tip
+// cap or fee cap) when a previously published attempt has not confirmed
+// within ResubmissionTimeout. attempt counts the number of bumps applied so
+// far for the current Send invocation, starting at 1 for the first bump
+// after the initial publication. Implementations should also return promptly
+// when the context is canceled.
+type GasPricer interface {
+	Next(ctx context.Context, attempt int, last *big.Int) (*big.Int, error)
+}
+
+// LinearBumper reproduces SimpleTxManager's original fee-bumping behavior:
+// every call to Next multiplies last by a fixed percentage.
+type LinearBumper struct {
+	// Multiplier is the percentage (e.g. 150 for 1.5x) applied to last on
+	// each call to Next.
+	Multiplier uint64
+}
+
+// Next multiplies last by Multiplier, expressed as an integer percentage.
+func (b *LinearBumper) Next(
+	_ context.Context, _ int, last *big.Int,
+) (*big.Int, error) {
+
+	return bumpByMultiplier(last, b.Multiplier), nil
+}
+
+// GeometricBumper bumps last by a fixed ratio on every call to Next, e.g.
+// 1.125 to match the minimum 12.5% increase go-ethereum requires for a
+// replacement transaction to be accepted into the mempool, clamped to a
+// configurable ceiling.
+type GeometricBumper struct {
+	// Ratio is the multiplier applied to last on each call to Next.
+	Ratio float64
+
+	// Ceiling is the maximum value Next will ever return. A nil Ceiling
+	// leaves the result unclamped.
+	Ceiling *big.Int
+}
+
+// Next multiplies last by Ratio, clamping the result to Ceiling if set.
+func (b *GeometricBumper) Next(
+	_ context.Context, _ int, last *big.Int,
+) (*big.Int, error) {
+
+	lastF := new(big.Float).SetInt(last)
+	bumpedF := new(big.Float).Mul(lastF, big.NewFloat(b.Ratio))
+
+	bumped, _ := bumpedF.Int(nil)
+	if b.Ceiling != nil && bumped.Cmp(b.Ceiling) > 0 {
+		bumped = new(big.Int).Set(b.Ceiling)
+	}
+
+	return bumped, nil
+}
+
+// FeeHistoryBackend is the backend functionality required by an OracleBumper
+// to sample recent on-chain priority fees.
+type FeeHistoryBackend interface {
+	// FeeHistory returns the fee market history for the most recent
+	// blockCount blocks ending at lastBlock (or the latest block, if
+	// lastBlock is nil), sampling rewardPercentiles from each block.
+	FeeHistory(
+		ctx context.Context, blockCount uint64, lastBlock *big.Int,
+		rewardPercentiles []float64,
+	) (*ethereum.FeeHistory, error)
+}
+
+// OracleBumper ignores last and instead derives the next value from the
+// average of a chosen reward percentile across recent blocks, via
+// eth_feeHistory. This tracks prevailing L1 conditions more closely than a
+// fixed multiplier on chains with volatile gas markets.
+type OracleBumper struct {
+	Backend FeeHistoryBackend
+
+	// BlockCount is the number of recent blocks to sample.
+	BlockCount uint64
+
+	// Percentile is the reward percentile, in [0, 100], to select from
+	// each sampled block.
+	Percentile float64
+}
+
+// Next samples Percentile's priority fee across the last BlockCount blocks
+// and returns their average. If the backend reports no reward data, last is
+// returned unchanged.
+func (b *OracleBumper) Next(
+	ctx context.Context, _ int, last *big.Int,
+) (*big.Int, error) {
+
+	history, err := b.Backend.FeeHistory(
+		ctx, b.BlockCount, nil, []float64{b.Percentile},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := new(big.Int)
+	var n int64
+	for _, rewards := range history.Reward {
+		if len(rewards) == 0 {
+			continue
+		}
+		sum.Add(sum, rewards[0])
+		n++
+	}
+	if n == 0 {
+		return last, nil
+	}
+
+	return sum.Div(sum, big.NewInt(n)), nil
+}
@@ -0,0 +1,105 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// TestLinearBumperAppliesMultiplier verifies that LinearBumper scales last by
+// the configured integer percentage.
+func TestLinearBumperAppliesMultiplier(t *testing.T) {
+	bumper := &LinearBumper{Multiplier: 150}
+
+	got, err := bumper.Next(context.Background(), 1, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("Next returned error: %v", err)
+	}
+	if want := big.NewInt(150); got.Cmp(want) != 0 {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+}
+
+// TestGeometricBumperAppliesRatioAndClampsToCeiling verifies that
+// GeometricBumper scales last by Ratio, and that a configured Ceiling clamps
+// the result rather than letting it grow unbounded.
+func TestGeometricBumperAppliesRatioAndClampsToCeiling(t *testing.T) {
+	bumper := &GeometricBumper{Ratio: 1.125}
+
+	got, err := bumper.Next(context.Background(), 1, big.NewInt(1000))
+	if err != nil {
+		t.Fatalf("Next returned error: %v", err)
+	}
+	if want := big.NewInt(1125); got.Cmp(want) != 0 {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+
+	bumper.Ceiling = big.NewInt(1100)
+	got, err = bumper.Next(context.Background(), 2, big.NewInt(1000))
+	if err != nil {
+		t.Fatalf("Next returned error: %v", err)
+	}
+	if got.Cmp(bumper.Ceiling) != 0 {
+		t.Errorf("Next = %v, want Ceiling (%v)", got, bumper.Ceiling)
+	}
+}
+
+// fakeFeeHistoryBackend is a FeeHistoryBackend whose FeeHistory response is
+// supplied directly by the test.
+type fakeFeeHistoryBackend struct {
+	history *ethereum.FeeHistory
+	err     error
+}
+
+func (b *fakeFeeHistoryBackend) FeeHistory(
+	_ context.Context, _ uint64, _ *big.Int, _ []float64,
+) (*ethereum.FeeHistory, error) {
+	return b.history, b.err
+}
+
+// TestOracleBumperAveragesRewards verifies that OracleBumper returns the
+// average of the sampled reward percentile across the reported blocks,
+// ignoring last entirely.
+func TestOracleBumperAveragesRewards(t *testing.T) {
+	backend := &fakeFeeHistoryBackend{
+		history: &ethereum.FeeHistory{
+			Reward: [][]*big.Int{
+				{big.NewInt(100)},
+				{big.NewInt(200)},
+				{big.NewInt(300)},
+			},
+		},
+	}
+	bumper := &OracleBumper{Backend: backend, BlockCount: 3, Percentile: 50}
+
+	got, err := bumper.Next(context.Background(), 1, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("Next returned error: %v", err)
+	}
+	if want := big.NewInt(200); got.Cmp(want) != 0 {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+}
+
+// TestOracleBumperFallsBackToLastWhenNoRewardData verifies that if the
+// backend reports no reward data for any sampled block, Next returns last
+// unchanged rather than dividing by zero.
+func TestOracleBumperFallsBackToLastWhenNoRewardData(t *testing.T) {
+	backend := &fakeFeeHistoryBackend{
+		history: &ethereum.FeeHistory{
+			Reward: [][]*big.Int{{}, {}},
+		},
+	}
+	bumper := &OracleBumper{Backend: backend, BlockCount: 2, Percentile: 50}
+
+	last := big.NewInt(42)
+	got, err := bumper.Next(context.Background(), 1, last)
+	if err != nil {
+		t.Fatalf("Next returned error: %v", err)
+	}
+	if got.Cmp(last) != 0 {
+		t.Errorf("Next = %v, want last (%v) unchanged", got, last)
+	}
+}
@@ -0,0 +1,513 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrNonceTooHigh is returned internally when the backend reports that a
+// published transaction's nonce is higher than the account's current nonce,
+// which indicates an earlier nonce in the sequence has not yet been mined.
+var ErrNonceTooHigh = errors.New("nonce too high")
+
+// TxID identifies a transaction submitted through a Sender. It is stable
+// across resubmissions of the same logical transaction, unlike the
+// transaction's hash or nonce, either of which may change as the Sender
+// bumps fees or recovers from a dropped nonce.
+type TxID uint64
+
+// TxConfirm reports the terminal outcome of a transaction submitted through
+// a Sender. Exactly one of Receipt or Err will be set.
+type TxConfirm struct {
+	// Receipt is the receipt of the confirmed transaction.
+	Receipt *types.Receipt
+
+	// Err explains why the transaction could not be confirmed.
+	Err error
+}
+
+// SenderSendFunc builds and signs a transaction for the given nonce, tip cap,
+// and fee cap. Implementations should also return promptly when the context
+// is canceled.
+type SenderSendFunc = func(
+	ctx context.Context, nonce uint64, tipCap, feeCap *big.Int,
+) (*types.Transaction, error)
+
+// SenderBackend is the backend functionality required by a Sender, beyond
+// that of a ReceiptSource, to submit transactions and keep its nonce in sync
+// with the account's on-chain state.
+type SenderBackend interface {
+	ReceiptSource
+
+	// PendingNonceAt retrieves the current pending nonce associated with
+	// the account.
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+
+	// SendTransaction injects a signed transaction into the pending pool
+	// for execution.
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// SenderConfig houses parameters for altering the behavior of a Sender.
+type SenderConfig struct {
+	Config
+
+	// SafeAbortNonceTooLowCount is the number of consecutive "nonce too
+	// low" errors that must be observed for a given attempt before the
+	// Sender gives up on it, under the assumption that the transaction was
+	// already mined under a hash the Sender no longer has visibility
+	// into.
+	SafeAbortNonceTooLowCount uint64
+
+	// Store, if set, persists the lifecycle of every attempt so that
+	// Resume can recover in-flight transactions after a crash rather than
+	// leaving their nonces permanently stuck.
+	Store Store
+}
+
+// TxState models the lifecycle of a single attempt persisted by a Store.
+type TxState int
+
+const (
+	// TxStateInProgress means the transaction has been published but no
+	// receipt has been observed.
+	TxStateInProgress TxState = iota
+
+	// TxStateConfirmed means the transaction reached NumConfirmations.
+	TxStateConfirmed
+
+	// TxStateFatalError means the attempt was abandoned, e.g. after
+	// reaching SafeAbortNonceTooLowCount.
+	TxStateFatalError
+
+	// TxStateSuperseded means a later attempt at the same nonce (e.g. a
+	// fee bump) was published before this one confirmed. The nonce's
+	// outcome is tracked under the later attempt's hash instead, and
+	// this one should no longer be treated as in flight.
+	TxStateSuperseded
+)
+
+// String implements fmt.Stringer.
+func (s TxState) String() string {
+	switch s {
+	case TxStateInProgress:
+		return "in_progress"
+	case TxStateConfirmed:
+		return "confirmed"
+	case TxStateFatalError:
+		return "fatal_error"
+	case TxStateSuperseded:
+		return "superseded"
+	default:
+		return "unknown"
+	}
+}
+
+// StoredAttempt is a single attempt as persisted by a Store.
+type StoredAttempt struct {
+	Nonce  uint64
+	Hash   common.Hash
+	RawTx  []byte
+	TipCap *big.Int
+	FeeCap *big.Int
+	State  TxState
+}
+
+// Store persists the lifecycle of transactions submitted through a Sender,
+// so that attempts still in flight when the process crashes are not
+// forgotten and their nonces left permanently stuck.
+type Store interface {
+	// SaveAttempt records the current state of a single attempt, keyed by
+	// nonce and hash. rawTx is the attempt's signed transaction in its
+	// canonical encoded form (types.Transaction.MarshalBinary), so that a
+	// resumed attempt can be rebroadcast without access to the original
+	// SenderSendFunc that built it.
+	SaveAttempt(nonce uint64, hash common.Hash, rawTx []byte, tipCap, feeCap *big.Int, state TxState) error
+
+	// LoadInFlight returns every attempt that has not reached
+	// TxStateConfirmed, TxStateFatalError, or TxStateSuperseded.
+	LoadInFlight() ([]StoredAttempt, error)
+
+	// MarkConfirmed transitions the attempt with the given hash to
+	// TxStateConfirmed.
+	MarkConfirmed(hash common.Hash) error
+}
+
+// txAttempt tracks the in-flight state of a single logical transaction
+// submitted through a Sender.
+type txAttempt struct {
+	id        TxID
+	nonce     uint64
+	confirmCh chan *TxConfirm
+}
+
+// Sender layers nonce management for a single signing account on top of a
+// SimpleTxManager. It allows multiple concurrent callers to submit
+// transactions for the same account without racing to assign the same
+// nonce, and recovers from transactions that are dropped from the mempool or
+// mined under a hash the Sender did not observe.
+type Sender struct {
+	cfg     SenderConfig
+	backend SenderBackend
+	account common.Address
+
+	mu       sync.Mutex
+	nonce    uint64
+	nextID   TxID
+	inFlight map[TxID]*txAttempt
+
+	wg sync.WaitGroup
+}
+
+// NewSender initializes a new Sender for account, using backend for
+// publication and confirmation. AdjustNonce should be called after
+// construction, or the Sender will begin assigning nonces starting from zero.
+func NewSender(
+	cfg SenderConfig, backend SenderBackend, account common.Address,
+) *Sender {
+
+	return &Sender{
+		cfg:      cfg,
+		backend:  backend,
+		account:  account,
+		inFlight: make(map[TxID]*txAttempt),
+	}
+}
+
+// AdjustNonce resyncs the Sender's next nonce with the account's current
+// pending nonce as reported by the backend. This should be called on
+// startup, and again any time an in-flight attempt is abandoned after
+// reaching SafeAbortNonceTooLowCount, since that indicates the Sender's
+// local view of the account's nonce has drifted from the chain.
+func (s *Sender) AdjustNonce(ctx context.Context) error {
+	nonceCtx, cancel := boundedCtx(ctx, s.cfg.NetworkTimeout)
+	nonce, err := s.backend.PendingNonceAt(nonceCtx, s.account)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.nonce = nonce
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Resume loads any attempts left in flight by a prior crash from the
+// configured Store, resumes watching each for confirmation, and advances the
+// Sender's nonce past the highest recorded attempt. If no Store is
+// configured, Resume simply calls AdjustNonce. It must be called once at
+// startup, before any call to SendTransaction.
+func (s *Sender) Resume(ctx context.Context) error {
+	if s.cfg.Store == nil {
+		return s.AdjustNonce(ctx)
+	}
+
+	attempts, err := s.cfg.Store.LoadInFlight()
+	if err != nil {
+		return err
+	}
+
+	var maxNonce uint64
+	var haveAttempts bool
+	for _, attempt := range attempts {
+		if !haveAttempts || attempt.Nonce+1 > maxNonce {
+			maxNonce = attempt.Nonce + 1
+		}
+		haveAttempts = true
+
+		s.wg.Add(1)
+		go s.resumeAttempt(ctx, attempt)
+	}
+
+	if err := s.AdjustNonce(ctx); err != nil {
+		return err
+	}
+
+	// PendingNonceAt already accounts for anything the backend's mempool
+	// knows about, but fall back to the Store's record in case one of our
+	// own attempts was dropped from the mempool entirely.
+	if haveAttempts {
+		s.mu.Lock()
+		if maxNonce > s.nonce {
+			s.nonce = maxNonce
+		}
+		s.mu.Unlock()
+	}
+
+	return nil
+}
+
+// resumeAttempt rebroadcasts a previously published attempt and
+// re-establishes a WaitMined watch for it, recording its terminal state
+// through the Store. Unlike a live txAttempt, a resumed attempt has no
+// confirmCh to report to: the caller that originally submitted it is gone
+// along with the crashed process, so its outcome is only ever recorded
+// through the Store.
+//
+// The rebroadcast happens unconditionally, before and after every reorg,
+// rather than only once: the crash may have happened before the original
+// SendTransaction call was ever acknowledged, in which case the backend's
+// mempool may have no record of the attempt at all. A rebroadcast of an
+// already-known tx is harmless.
+func (s *Sender) resumeAttempt(ctx context.Context, attempt StoredAttempt) {
+	defer s.wg.Done()
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(attempt.RawTx); err != nil {
+		log.Error("Unable to decode resumed attempt, abandoning",
+			"nonce", attempt.Nonce, "hash", attempt.Hash, "err", err)
+		return
+	}
+
+	for {
+		sendCtx, cancel := boundedCtx(ctx, s.cfg.NetworkTimeout)
+		err := s.backend.SendTransaction(sendCtx, tx)
+		cancel()
+		if err != nil && classifyBroadcastErr(err) != broadcastErrAlreadyKnown {
+			log.Warn("Unable to rebroadcast resumed attempt",
+				"nonce", attempt.Nonce, "hash", attempt.Hash, "err", err)
+		}
+
+		receipt, err := WaitMined(
+			ctx, s.backend, attempt.Hash, s.cfg.ReceiptQueryInterval,
+			s.cfg.NumConfirmations, s.cfg.NetworkTimeout,
+		)
+		if errors.Is(err, ErrTxReorged) {
+			log.Warn("Resumed attempt reorged out, rebroadcasting",
+				"nonce", attempt.Nonce, "hash", attempt.Hash)
+			continue
+		}
+		if err != nil {
+			log.Error("Unable to resume in-flight attempt",
+				"nonce", attempt.Nonce, "hash", attempt.Hash, "err", err)
+			return
+		}
+
+		log.Info("Resumed attempt confirmed", "nonce", attempt.Nonce,
+			"hash", attempt.Hash, "block", receipt.BlockNumber)
+		if err := s.cfg.Store.MarkConfirmed(attempt.Hash); err != nil {
+			log.Error("Unable to persist resumed confirmation",
+				"hash", attempt.Hash, "err", err)
+		}
+		return
+	}
+}
+
+// SendTransaction assigns the next available nonce to buildTx and publishes
+// the resulting transaction, bumping its tip cap and fee cap on the schedule
+// of the Sender's underlying SimpleTxManager until it confirms. It returns
+// immediately with a TxID identifying the attempt and a channel that
+// receives exactly one TxConfirm once the attempt reaches a terminal state.
+func (s *Sender) SendTransaction(
+	ctx context.Context, buildTx SenderSendFunc,
+) (TxID, <-chan *TxConfirm) {
+
+	s.mu.Lock()
+	nonce := s.nonce
+	s.nonce++
+	id := s.nextID
+	s.nextID++
+
+	attempt := &txAttempt{
+		id:        id,
+		nonce:     nonce,
+		confirmCh: make(chan *TxConfirm, 1),
+	}
+	s.inFlight[id] = attempt
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.run(ctx, attempt, buildTx)
+
+	return id, attempt.confirmCh
+}
+
+// run drives a single txAttempt to completion, publishing it through a
+// SimpleTxManager pinned to the attempt's nonce and reporting the terminal
+// outcome on the attempt's confirmCh.
+func (s *Sender) run(
+	ctx context.Context, attempt *txAttempt, buildTx SenderSendFunc,
+) {
+
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.inFlight, attempt.id)
+		s.mu.Unlock()
+	}()
+
+	// nonceTooLowCount is incremented from sendTx below on each "nonce
+	// too low" error and reset to zero by any other outcome, so it counts
+	// consecutive occurrences. SimpleTxManager.Send may invoke sendTx
+	// concurrently from more than one
+	// in-flight sendTxAsync goroutine (e.g. a resubmission racing a
+	// reorg recovery), so it must be accessed atomically.
+	var nonceTooLowCount uint64
+
+	// lastSaved tracks the most recently persisted hash for this nonce, so
+	// that when a later attempt (e.g. a fee bump) is saved, the one it
+	// supersedes can be transitioned out of TxStateInProgress instead of
+	// being left in the store as a phantom in-flight entry forever. Like
+	// nonceTooLowCount, sendTx can run concurrently across more than one
+	// goroutine for the same nonce, so access is guarded by a mutex.
+	var (
+		lastSavedMu     sync.Mutex
+		lastSavedHash   common.Hash
+		haveLastSaved   bool
+		lastSavedTipCap *big.Int
+		lastSavedFeeCap *big.Int
+	)
+
+	txMgr := NewSimpleTxManager(s.cfg.Config, s.backend)
+	sendTx := func(
+		ctx context.Context, tipCap, feeCap *big.Int,
+	) (*types.Transaction, error) {
+
+		tx, err := buildTx(ctx, attempt.nonce, tipCap, feeCap)
+		if err != nil {
+			return nil, err
+		}
+
+		sendCtx, cancel := boundedCtx(ctx, s.cfg.NetworkTimeout)
+		err = s.backend.SendTransaction(sendCtx, tx)
+		cancel()
+		if err == nil && s.cfg.Store != nil {
+			rawTx, marshalErr := tx.MarshalBinary()
+			if marshalErr != nil {
+				log.Error("Unable to encode attempt for persistence",
+					"nonce", attempt.nonce, "err", marshalErr)
+			}
+			saveErr := s.cfg.Store.SaveAttempt(
+				attempt.nonce, tx.Hash(), rawTx, tipCap, feeCap,
+				TxStateInProgress,
+			)
+			if saveErr != nil {
+				log.Error("Unable to persist attempt",
+					"nonce", attempt.nonce, "err", saveErr)
+			}
+
+			lastSavedMu.Lock()
+			prevHash, havePrev := lastSavedHash, haveLastSaved
+			prevTipCap, prevFeeCap := lastSavedTipCap, lastSavedFeeCap
+			lastSavedHash, haveLastSaved = tx.Hash(), true
+			lastSavedTipCap, lastSavedFeeCap = tipCap, feeCap
+			lastSavedMu.Unlock()
+
+			if havePrev && prevHash != tx.Hash() {
+				supersedeErr := s.cfg.Store.SaveAttempt(
+					attempt.nonce, prevHash, nil,
+					prevTipCap, prevFeeCap, TxStateSuperseded,
+				)
+				if supersedeErr != nil {
+					log.Error("Unable to persist superseded attempt",
+						"nonce", attempt.nonce, "hash", prevHash,
+						"err", supersedeErr)
+				}
+			}
+		}
+
+		errKind := classifyBroadcastErr(err)
+		if errKind != broadcastErrNonceTooLow {
+			atomic.StoreUint64(&nonceTooLowCount, 0)
+		}
+
+		switch errKind {
+		case broadcastErrNonceTooLow:
+			count := atomic.AddUint64(&nonceTooLowCount, 1)
+			log.Warn("Nonce too low, tx may already be mined",
+				"nonce", attempt.nonce, "count", count)
+
+			if count >= s.cfg.SafeAbortNonceTooLowCount {
+				if s.cfg.Store != nil {
+					rawTx, _ := tx.MarshalBinary()
+					saveErr := s.cfg.Store.SaveAttempt(
+						attempt.nonce, tx.Hash(), rawTx,
+						tipCap, feeCap, TxStateFatalError,
+					)
+					if saveErr != nil {
+						log.Error("Unable to persist aborted attempt",
+							"nonce", attempt.nonce, "err", saveErr)
+					}
+				}
+				return nil, ErrPublishTimeout
+			}
+			return nil, err
+
+		case broadcastErrNonceTooHigh:
+			log.Warn("Nonce too high, an earlier tx is still pending",
+				"nonce", attempt.nonce)
+			return nil, ErrNonceTooHigh
+
+		case broadcastErrReplacementUnderpriced:
+			log.Debug("Replacement transaction underpriced, will bump",
+				"nonce", attempt.nonce)
+			return nil, err
+
+		case broadcastErrAlreadyKnown:
+			// The backend already has this exact tx in its
+			// mempool; this isn't a failure.
+			return tx, nil
+
+		default:
+			return tx, err
+		}
+	}
+
+	receipt, err := txMgr.Send(ctx, sendTx)
+	if err == nil && s.cfg.Store != nil {
+		if markErr := s.cfg.Store.MarkConfirmed(receipt.TxHash); markErr != nil {
+			log.Error("Unable to persist confirmation",
+				"nonce", attempt.nonce, "hash", receipt.TxHash,
+				"err", markErr)
+		}
+	}
+
+	attempt.confirmCh <- &TxConfirm{
+		Receipt: receipt,
+		Err:     err,
+	}
+}
+
+// broadcastErr classifies the error strings returned by common Ethereum
+// clients when a transaction is rejected from the mempool.
+type broadcastErr int
+
+const (
+	broadcastErrUnknown broadcastErr = iota
+	broadcastErrNonceTooLow
+	broadcastErrNonceTooHigh
+	broadcastErrReplacementUnderpriced
+	broadcastErrAlreadyKnown
+)
+
+// classifyBroadcastErr inspects err's message for the substrings used by
+// go-ethereum and its major forks to signal nonce and replacement conflicts.
+func classifyBroadcastErr(err error) broadcastErr {
+	if err == nil {
+		return broadcastErrUnknown
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "nonce too low"):
+		return broadcastErrNonceTooLow
+	case strings.Contains(msg, "nonce too high"):
+		return broadcastErrNonceTooHigh
+	case strings.Contains(msg, "replacement transaction underpriced"):
+		return broadcastErrReplacementUnderpriced
+	case strings.Contains(msg, "already known"):
+		return broadcastErrAlreadyKnown
+	default:
+		return broadcastErrUnknown
+	}
+}
@@ -0,0 +1,354 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// nonceTooLowBackend is a SenderBackend whose SendTransaction always reports
+// "nonce too low", used to drive a Sender's abort path.
+type nonceTooLowBackend struct {
+	baseFee *big.Int
+	calls   int32
+}
+
+func (b *nonceTooLowBackend) TransactionReceipt(
+	_ context.Context, _ common.Hash,
+) (*types.Receipt, error) {
+	return nil, nil
+}
+
+func (b *nonceTooLowBackend) HeaderByNumber(
+	_ context.Context, _ *big.Int,
+) (*types.Header, error) {
+	return &types.Header{BaseFee: b.baseFee}, nil
+}
+
+func (b *nonceTooLowBackend) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (b *nonceTooLowBackend) TransactionByHash(
+	_ context.Context, _ common.Hash,
+) (*types.Transaction, bool, error) {
+	return nil, false, nil
+}
+
+func (b *nonceTooLowBackend) PendingNonceAt(
+	_ context.Context, _ common.Address,
+) (uint64, error) {
+	return 0, nil
+}
+
+func (b *nonceTooLowBackend) SendTransaction(
+	_ context.Context, _ *types.Transaction,
+) error {
+	atomic.AddInt32(&b.calls, 1)
+	return errors.New("nonce too low")
+}
+
+// deadlineCapturingBackend is a SenderBackend that records whether the
+// context passed to PendingNonceAt and SendTransaction carried a deadline.
+type deadlineCapturingBackend struct {
+	nonceTooLowBackend
+
+	mu                      sync.Mutex
+	pendingNonceDeadline    bool
+	sendTransactionDeadline bool
+}
+
+func (b *deadlineCapturingBackend) PendingNonceAt(
+	ctx context.Context, account common.Address,
+) (uint64, error) {
+	_, ok := ctx.Deadline()
+	b.mu.Lock()
+	b.pendingNonceDeadline = ok
+	b.mu.Unlock()
+	return b.nonceTooLowBackend.PendingNonceAt(ctx, account)
+}
+
+func (b *deadlineCapturingBackend) SendTransaction(
+	ctx context.Context, tx *types.Transaction,
+) error {
+	_, ok := ctx.Deadline()
+	b.mu.Lock()
+	b.sendTransactionDeadline = ok
+	b.mu.Unlock()
+	return b.nonceTooLowBackend.SendTransaction(ctx, tx)
+}
+
+// TestSenderAppliesNetworkTimeout verifies that AdjustNonce's PendingNonceAt
+// call and run's SendTransaction call are both bounded by NetworkTimeout,
+// matching every other backend RPC call the package makes.
+func TestSenderAppliesNetworkTimeout(t *testing.T) {
+	backend := &deadlineCapturingBackend{
+		nonceTooLowBackend: nonceTooLowBackend{baseFee: big.NewInt(1)},
+	}
+
+	cfg := SenderConfig{
+		Config: Config{
+			MinTipCap:            big.NewInt(1),
+			MinBaseFee:           big.NewInt(1),
+			FeeLimitMultiplier:   150,
+			FeeLimitThreshold:    big.NewInt(1_000_000),
+			ResubmissionTimeout:  time.Hour,
+			ReceiptQueryInterval: 10 * time.Millisecond,
+			NetworkTimeout:       time.Second,
+		},
+		SafeAbortNonceTooLowCount: 1,
+	}
+
+	sender := NewSender(cfg, backend, common.Address{})
+
+	if err := sender.AdjustNonce(context.Background()); err != nil {
+		t.Fatalf("AdjustNonce returned error: %v", err)
+	}
+
+	backend.mu.Lock()
+	gotNonceDeadline := backend.pendingNonceDeadline
+	backend.mu.Unlock()
+	if !gotNonceDeadline {
+		t.Error("PendingNonceAt was not called with a bounded context")
+	}
+
+	buildTx := func(
+		_ context.Context, nonce uint64, _, feeCap *big.Int,
+	) (*types.Transaction, error) {
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			To:       &common.Address{},
+			Value:    big.NewInt(0),
+			Gas:      21000,
+			GasPrice: feeCap,
+		}), nil
+	}
+
+	_, confirmCh := sender.SendTransaction(context.Background(), buildTx)
+	select {
+	case <-confirmCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("run did not complete")
+	}
+
+	backend.mu.Lock()
+	gotSendDeadline := backend.sendTransactionDeadline
+	backend.mu.Unlock()
+	if !gotSendDeadline {
+		t.Error("SendTransaction was not called with a bounded context")
+	}
+}
+
+// fakeStore is an in-memory Store used to observe what run persists without
+// standing up a BoltStore.
+type fakeStore struct {
+	mu    sync.Mutex
+	saved []StoredAttempt
+}
+
+func (s *fakeStore) SaveAttempt(
+	nonce uint64, hash common.Hash, rawTx []byte, tipCap, feeCap *big.Int,
+	state TxState,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved = append(s.saved, StoredAttempt{
+		Nonce: nonce, Hash: hash, RawTx: rawTx, TipCap: tipCap,
+		FeeCap: feeCap, State: state,
+	})
+	return nil
+}
+
+func (s *fakeStore) LoadInFlight() ([]StoredAttempt, error) {
+	return nil, nil
+}
+
+func (s *fakeStore) MarkConfirmed(_ common.Hash) error {
+	return nil
+}
+
+// TestRunAbortsAfterSafeAbortNonceTooLowCount verifies that once
+// SendTransaction repeatedly reports "nonce too low", run gives up after
+// SafeAbortNonceTooLowCount attempts and reports ErrPublishTimeout on the
+// attempt's confirmCh, rather than letting SimpleTxManager.Send keep
+// bumping and resubmitting the exhausted nonce forever.
+func TestRunAbortsAfterSafeAbortNonceTooLowCount(t *testing.T) {
+	backend := &nonceTooLowBackend{baseFee: big.NewInt(1)}
+	store := &fakeStore{}
+
+	cfg := SenderConfig{
+		Config: Config{
+			MinTipCap:            big.NewInt(1),
+			MinBaseFee:           big.NewInt(1),
+			FeeLimitMultiplier:   150,
+			FeeLimitThreshold:    big.NewInt(1_000_000),
+			ResubmissionTimeout:  10 * time.Millisecond,
+			ReceiptQueryInterval: 10 * time.Millisecond,
+		},
+		SafeAbortNonceTooLowCount: 3,
+		Store:                     store,
+	}
+
+	sender := NewSender(cfg, backend, common.Address{})
+
+	buildTx := func(
+		_ context.Context, nonce uint64, _, feeCap *big.Int,
+	) (*types.Transaction, error) {
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			To:       &common.Address{},
+			Value:    big.NewInt(0),
+			Gas:      21000,
+			GasPrice: feeCap,
+		}), nil
+	}
+
+	_, confirmCh := sender.SendTransaction(context.Background(), buildTx)
+
+	select {
+	case confirm := <-confirmCh:
+		if !errors.Is(confirm.Err, ErrPublishTimeout) {
+			t.Fatalf("confirm.Err = %v, want ErrPublishTimeout", confirm.Err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("run did not abort after SafeAbortNonceTooLowCount attempts")
+	}
+
+	if got := atomic.LoadInt32(&backend.calls); got < 3 {
+		t.Errorf("SendTransaction called %d times, want at least 3", got)
+	}
+}
+
+// resumeConfirmBackend is a SenderBackend that always reports a confirmed
+// receipt for any tx hash, used to verify that a resumed attempt is
+// rebroadcast and then confirmed.
+type resumeConfirmBackend struct {
+	sendCalls int32
+}
+
+func (b *resumeConfirmBackend) TransactionReceipt(
+	_ context.Context, _ common.Hash,
+) (*types.Receipt, error) {
+	return &types.Receipt{
+		BlockHash: common.HexToHash("0xccc"), BlockNumber: big.NewInt(1),
+	}, nil
+}
+
+func (b *resumeConfirmBackend) HeaderByNumber(
+	_ context.Context, _ *big.Int,
+) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(1)}, nil
+}
+
+func (b *resumeConfirmBackend) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (b *resumeConfirmBackend) TransactionByHash(
+	_ context.Context, _ common.Hash,
+) (*types.Transaction, bool, error) {
+	return nil, false, nil
+}
+
+func (b *resumeConfirmBackend) PendingNonceAt(
+	_ context.Context, _ common.Address,
+) (uint64, error) {
+	return 1, nil
+}
+
+func (b *resumeConfirmBackend) SendTransaction(
+	_ context.Context, _ *types.Transaction,
+) error {
+	atomic.AddInt32(&b.sendCalls, 1)
+	return nil
+}
+
+// resumeStore is an in-memory Store that serves a fixed set of in-flight
+// attempts from LoadInFlight and records every MarkConfirmed call.
+type resumeStore struct {
+	attempts []StoredAttempt
+
+	mu        sync.Mutex
+	confirmed []common.Hash
+}
+
+func (s *resumeStore) SaveAttempt(
+	uint64, common.Hash, []byte, *big.Int, *big.Int, TxState,
+) error {
+	return nil
+}
+
+func (s *resumeStore) LoadInFlight() ([]StoredAttempt, error) {
+	return s.attempts, nil
+}
+
+func (s *resumeStore) MarkConfirmed(hash common.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.confirmed = append(s.confirmed, hash)
+	return nil
+}
+
+// TestResumeRebroadcastsInFlightAttempt verifies that Resume drives a loaded
+// in-flight attempt through an actual rebroadcast before watching it for
+// confirmation, rather than a bare WaitMined that can never recover a tx
+// dropped from the mempool before the crash.
+func TestResumeRebroadcastsInFlightAttempt(t *testing.T) {
+	backend := &resumeConfirmBackend{}
+
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    0,
+		To:       &common.Address{},
+		Value:    big.NewInt(0),
+		Gas:      21000,
+		GasPrice: big.NewInt(1),
+	})
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary returned error: %v", err)
+	}
+
+	store := &resumeStore{attempts: []StoredAttempt{{
+		Nonce:  0,
+		Hash:   tx.Hash(),
+		RawTx:  rawTx,
+		TipCap: big.NewInt(1),
+		FeeCap: big.NewInt(1),
+		State:  TxStateInProgress,
+	}}}
+
+	cfg := SenderConfig{
+		Config: Config{
+			MinTipCap:            big.NewInt(1),
+			MinBaseFee:           big.NewInt(1),
+			FeeLimitMultiplier:   150,
+			FeeLimitThreshold:    big.NewInt(1_000_000),
+			ReceiptQueryInterval: 10 * time.Millisecond,
+		},
+		Store: store,
+	}
+
+	sender := NewSender(cfg, backend, common.Address{})
+
+	if err := sender.Resume(context.Background()); err != nil {
+		t.Fatalf("Resume returned error: %v", err)
+	}
+	sender.wg.Wait()
+
+	if got := atomic.LoadInt32(&backend.sendCalls); got != 1 {
+		t.Errorf("SendTransaction called %d times, want 1 (rebroadcast of resumed attempt)", got)
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.confirmed) != 1 || store.confirmed[0] != tx.Hash() {
+		t.Errorf("MarkConfirmed calls = %v, want [%v]", store.confirmed, tx.Hash())
+	}
+}
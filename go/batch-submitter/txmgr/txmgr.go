@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -17,38 +18,106 @@ import (
 // resubmission timeout.
 var ErrPublishTimeout = errors.New("failed to publish tx with max gas price")
 
+// ErrTxReorged signals that a previously observed receipt for a tx has
+// disappeared, or been reassigned to a different block, before it reached
+// NumConfirmations. This indicates the tx was orphaned by a reorg and should
+// be resubmitted rather than treated as confirmed.
+var ErrTxReorged = errors.New("tx receipt reorged out before confirmation depth reached")
+
+// ErrTxNotInMempool signals that Send's context ended (e.g. TxSendTimeout
+// elapsed) without a confirmation, after at least one published tx had been
+// found missing from the backend's mempool once TxNotInMempoolTimeout passed.
+var ErrTxNotInMempool = errors.New("tx not found in mempool")
+
 // SendTxFunc defines a function signature for publishing a desired tx with a
-// specific gas price. Implementations of this signature should also return
-// promptly when the context is canceled.
+// specific tip cap and fee cap. Implementations of this signature should also
+// return promptly when the context is canceled.
 type SendTxFunc = func(
+	ctx context.Context, tipCap, feeCap *big.Int) (*types.Transaction, error)
+
+// LegacySendTxFunc defines a function signature for publishing a desired tx
+// with a single, flat gas price. This matches the pre-EIP-1559 SendTxFunc
+// signature and is kept around so that existing callers do not need to be
+// rewritten all at once.
+type LegacySendTxFunc = func(
 	ctx context.Context, gasPrice *big.Int) (*types.Transaction, error)
 
-// Config houses parameters for altering the behavior of a SimpleTxManager.
-type Config struct {
-	// MinGasPrice is the minimum gas price (in gwei). This is used as the
-	// initial publication attempt.
-	MinGasPrice *big.Int
+// AdaptLegacySendTxFunc wraps a LegacySendTxFunc in a SendTxFunc so that it
+// can be driven by a SimpleTxManager configured for EIP-1559 fee bumping. The
+// computed feeCap is forwarded to legacy as a flat gas price, and tipCap is
+// discarded since legacy transactions have no independent tip.
+func AdaptLegacySendTxFunc(legacy LegacySendTxFunc) SendTxFunc {
+	return func(
+		ctx context.Context, tipCap, feeCap *big.Int,
+	) (*types.Transaction, error) {
 
-	// MaxGasPrice is the maximum gas price (in gwei). This is used to clamp
-	// the upper end of the range that the TxManager will ever publish when
-	// attempting to confirm a transaction.
-	MaxGasPrice *big.Int
+		return legacy(ctx, feeCap)
+	}
+}
 
-	// GasRetryIncrement is the additive gas price (in gwei) that will be
-	// used to bump each successive tx after a ResubmissionTimeout has
-	// elapsed.
-	GasRetryIncrement *big.Int
+// Config houses parameters for altering the behavior of a SimpleTxManager.
+type Config struct {
+	// MinTipCap is the minimum suggested priority fee (tip cap, in wei)
+	// that will ever be used to publish a transaction. This is used as the
+	// initial publication attempt, and as a floor when bumping the tip cap
+	// of subsequent attempts.
+	MinTipCap *big.Int
+
+	// MinBaseFee is the minimum base fee (in wei) assumed when computing
+	// the fee cap of a new attempt, even if the backend reports a lower
+	// base fee for the latest block.
+	MinBaseFee *big.Int
+
+	// FeeLimitMultiplier is the multiplier, expressed as an integer
+	// percentage (e.g. 150 for 1.5x), applied to the last fee cap and tip
+	// cap when bumping an attempt that has not been mined within
+	// ResubmissionTimeout.
+	FeeLimitMultiplier uint64
+
+	// FeeLimitThreshold is the maximum fee cap (in wei) that the tx
+	// manager will ever use to publish a transaction. This clamps the
+	// upper end of the range that bumping can reach.
+	FeeLimitThreshold *big.Int
 
 	// ResubmissionTimeout is the interval at which, if no previously
-	// published transaction has been mined, the new tx with a bumped gas
-	// price will be published. Only one publication at MaxGasPrice will be
-	// attempted.
+	// published transaction has been mined, the new tx with a bumped fee
+	// cap and tip cap will be published. Only one publication at
+	// FeeLimitThreshold will be attempted.
 	ResubmissionTimeout time.Duration
 
 	// RequireQueryInterval is the interval at which the tx manager will
 	// query the backend to check for confirmations after a tx at a
 	// specific gas price has been published.
 	ReceiptQueryInterval time.Duration
+
+	// NumConfirmations is the number of blocks that must build on top of
+	// the block containing a tx's receipt before WaitMined will treat the
+	// tx as confirmed. This guards against shallow L1 reorgs orphaning a
+	// tx that briefly appeared mined.
+	NumConfirmations uint64
+
+	// NetworkTimeout bounds the duration of each individual RPC call the
+	// tx manager makes to the backend (receipt, header, and mempool
+	// lookups). A zero value leaves such calls bounded only by the
+	// context passed into Send.
+	NetworkTimeout time.Duration
+
+	// TxSendTimeout bounds the total duration of a single call to Send,
+	// across all of its resubmissions. A zero value leaves Send bounded
+	// only by the context passed in by the caller.
+	TxSendTimeout time.Duration
+
+	// TxNotInMempoolTimeout is the duration to wait, after a tx has been
+	// published, before checking whether the backend's mempool still has
+	// visibility into it. If the tx is found to be missing, Send
+	// republishes it immediately, independent of ResubmissionTimeout.
+	TxNotInMempoolTimeout time.Duration
+
+	// GasPricer selects the bumping strategy used to compute the next tip
+	// cap and fee cap once ResubmissionTimeout has elapsed without a
+	// confirmation. If nil, a LinearBumper using FeeLimitMultiplier is
+	// used, matching the manager's original behavior.
+	GasPricer GasPricer
 }
 
 // TxManager is an interface that allows callers to reliably publish txs,
@@ -63,20 +132,49 @@ type TxManager interface {
 	Send(ctx context.Context, sendTx SendTxFunc) (*types.Receipt, error)
 }
 
-// ReceiptSource is a minimal function signature used to detect the confirmation
-// of published txs.
+// ReceiptSource is a minimal function signature used to detect the
+// confirmation of published txs and to compute EIP-1559 fees.
 //
-// NOTE: This is a subset of bind.DeployBackend.
+// NOTE: This is a subset of bind.DeployBackend and bind.ContractBackend.
 type ReceiptSource interface {
 	// TransactionReceipt queries the backend for a receipt associated with
 	// txHash. If lookup does not fail, but the transaction is not found,
 	// nil should be returned for both values.
 	TransactionReceipt(
 		ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+
+	// HeaderByNumber returns the header of the block with the given
+	// number, or the latest known header if number is nil.
+	HeaderByNumber(
+		ctx context.Context, number *big.Int) (*types.Header, error)
+
+	// SuggestGasTipCap retrieves the currently suggested gas tip cap to
+	// allow a timely execution of a transaction.
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+
+	// TransactionByHash returns the transaction with the given hash, and
+	// whether it is still pending (unmined). This mirrors
+	// ethclient.Client.TransactionByHash and is used to detect a
+	// published tx that has been dropped from the mempool.
+	TransactionByHash(
+		ctx context.Context, txHash common.Hash,
+	) (tx *types.Transaction, isPending bool, err error)
 }
 
-// SimpleTxManager is a implementation of TxManager that performs linear fee
-// bumping of a tx until it confirms.
+// boundedCtx derives a context bounded by timeout, for use around a single
+// backend RPC call. If timeout is zero, ctx is returned unchanged.
+func boundedCtx(
+	ctx context.Context, timeout time.Duration,
+) (context.Context, context.CancelFunc) {
+
+	if timeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// SimpleTxManager is a implementation of TxManager that performs fee bumping
+// of a tx until it confirms.
 type SimpleTxManager struct {
 	cfg     Config
 	backend ReceiptSource
@@ -99,9 +197,16 @@ func NewSimpleTxManager(cfg Config, backend ReceiptSource) *SimpleTxManager {
 func (m *SimpleTxManager) Send(
 	ctx context.Context, sendTx SendTxFunc) (*types.Receipt, error) {
 
+	// Bound the total duration of this call, independent of the caller's
+	// own context, if configured.
+	if m.cfg.TxSendTimeout > 0 {
+		var cancelSend context.CancelFunc
+		ctx, cancelSend = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
+		defer cancelSend()
+	}
+
 	// Initialize a wait group to track any spawned goroutines, and ensure
 	// we properly clean up any dangling resources this method generates.
-	// We assert that this is the case thoroughly in our unit tests.
 	var wg sync.WaitGroup
 	defer wg.Wait()
 
@@ -113,32 +218,79 @@ func (m *SimpleTxManager) Send(
 
 	// Create a closure that will block on passed sendTx function in the
 	// background, returning the first successfully mined receipt back to
-	// the main event loop via receiptChan.
+	// the main event loop via receiptChan. notInMempoolChan is signaled
+	// separately, as soon as any in-flight attempt is observed missing
+	// from the backend's mempool.
 	receiptChan := make(chan *types.Receipt, 1)
-	sendTxAsync := func(gasPrice *big.Int) {
+	notInMempoolChan := make(chan struct{}, 1)
+
+	// abortChan carries a terminal error out of a sendTxAsync goroutine
+	// and into the main event loop, for the case where sendTx itself
+	// determines the attempt can never succeed (e.g. a Sender that has
+	// reached SafeAbortNonceTooLowCount). Without this, such an error
+	// was only ever logged from within the goroutine, and Send would
+	// keep bumping fees and resubmitting indefinitely.
+	abortChan := make(chan error, 1)
+
+	// sendTxAsync is predeclared so that its body can call itself by name
+	// when resubmitting after a reorg, rather than referring to a
+	// variable that is still being initialized.
+	var sendTxAsync func(tipCap, feeCap *big.Int)
+	sendTxAsync = func(tipCap, feeCap *big.Int) {
 		defer wg.Done()
 
-		// Sign and publish transaction with current gas price.
-		tx, err := sendTx(ctxc, gasPrice)
+		// Sign and publish transaction with current tip cap and fee
+		// cap.
+		tx, err := sendTx(ctxc, tipCap, feeCap)
 		if err != nil {
 			log.Error("Unable to publish transaction",
-				"gas_price", gasPrice, "err", err)
-			// TODO(conner): add retry?
+				"tip_cap", tipCap, "fee_cap", feeCap, "err", err)
+			if errors.Is(err, ErrPublishTimeout) {
+				// sendTx has determined this attempt can
+				// never succeed. Surface it to the main
+				// event loop instead of leaving this
+				// goroutine to die silently while Send
+				// keeps resubmitting.
+				select {
+				case abortChan <- err:
+				default:
+				}
+			}
 			return
 		}
 
 		txHash := tx.Hash()
 		log.Info("Transaction published successfully", "hash", txHash,
-			"gas_price", gasPrice)
+			"tip_cap", tipCap, "fee_cap", feeCap)
+
+		// Watch for this attempt falling out of the mempool well
+		// before the next scheduled resubmission.
+		if m.cfg.TxNotInMempoolTimeout > 0 {
+			wg.Add(1)
+			go m.watchMempool(ctxc, &wg, tx, notInMempoolChan)
+		}
 
 		// Wait for the transaction to be mined, reporting the receipt
 		// back to the main event loop if found.
 		receipt, err := WaitMined(
-			ctxc, m.backend, tx, m.cfg.ReceiptQueryInterval,
+			ctxc, m.backend, txHash, m.cfg.ReceiptQueryInterval,
+			m.cfg.NumConfirmations, m.cfg.NetworkTimeout,
 		)
+		if errors.Is(err, ErrTxReorged) {
+			// The tx was orphaned before reaching our required
+			// confirmation depth. Resubmit at the same tip cap
+			// and fee cap rather than treating this attempt as
+			// confirmed or bumping prematurely.
+			log.Warn("Transaction reorged out, resubmitting",
+				"hash", txHash, "tip_cap", tipCap,
+				"fee_cap", feeCap)
+			wg.Add(1)
+			go sendTxAsync(tipCap, feeCap)
+			return
+		}
 		if err != nil {
 			log.Trace("Send tx failed", "hash", txHash,
-				"gas_price", gasPrice, "err", err)
+				"tip_cap", tipCap, "fee_cap", feeCap, "err", err)
 		}
 		if receipt != nil {
 			// Use non-blocking select to ensure function can exit
@@ -146,49 +298,90 @@ func (m *SimpleTxManager) Send(
 			select {
 			case receiptChan <- receipt:
 				log.Trace("Send tx succeeded", "hash", txHash,
-					"gas_price", gasPrice)
+					"tip_cap", tipCap, "fee_cap", feeCap)
 			default:
 			}
 		}
 	}
 
-	// Initialize our initial gas price to the configured minimum.
-	curGasPrice := new(big.Int).Set(m.cfg.MinGasPrice)
+	// Compute our initial tip cap and fee cap from the backend's current
+	// view of the fee market, rather than publishing at the configured
+	// minimums unconditionally. This keeps the first attempt no less
+	// likely to be included than a bumped resubmission would be.
+	curTipCap, curFeeCap, err := m.initialGasPrice(ctxc)
+	if err != nil {
+		return nil, err
+	}
 
-	// Submit and wait for the receipt at our first gas price in the
-	// background, before entering the event loop and waiting out the
+	// Submit and wait for the receipt at our first tip cap and fee cap in
+	// the background, before entering the event loop and waiting out the
 	// resubmission timeout.
 	wg.Add(1)
-	go sendTxAsync(curGasPrice)
+	go sendTxAsync(curTipCap, curFeeCap)
+
+	// sawNotInMempool records whether any attempt was ever observed
+	// missing from the mempool, so that if Send's own deadline expires we
+	// can surface ErrTxNotInMempool instead of a bare context error.
+	var sawNotInMempool bool
+
+	// attempt counts the number of bumps applied so far, and is handed to
+	// the configured GasPricer so that strategies which vary by attempt
+	// number (rather than purely by last value) can do so.
+	var attempt int
 
 	for {
 		select {
 
-		// Whenever a resubmission timeout has elapsed, bump the gas
-		// price and publish a new transaction.
+		// Whenever a resubmission timeout has elapsed, bump the fees
+		// and publish a new transaction.
 		case <-time.After(m.cfg.ResubmissionTimeout):
-			// If our last attempt published at the max gas price,
-			// return an error as we are unlikely to succeed in
-			// publishing. This also indicates that the max gas
-			// price should likely be adjusted higher for the
-			// daemon.
-			if curGasPrice.Cmp(m.cfg.MaxGasPrice) >= 0 {
+			// If our last attempt published at the fee limit
+			// threshold, return an error as we are unlikely to
+			// succeed in publishing. This also indicates that the
+			// fee limit threshold should likely be adjusted higher
+			// for the daemon.
+			if curFeeCap.Cmp(m.cfg.FeeLimitThreshold) >= 0 {
 				return nil, ErrPublishTimeout
 			}
 
-			// Bump the gas price using linear gas price increments.
-			curGasPrice = NextGasPrice(
-				curGasPrice, m.cfg.GasRetryIncrement,
-				m.cfg.MaxGasPrice,
+			// Bump the tip cap and fee cap using the latest base
+			// fee observed by the backend.
+			attempt++
+			var err error
+			curTipCap, curFeeCap, err = m.NextGasPrice(
+				ctxc, attempt, curTipCap, curFeeCap,
 			)
+			if err != nil {
+				log.Error("Unable to compute next fees",
+					"err", err)
+				continue
+			}
 
 			// Submit and wait for the bumped traction to confirm.
 			wg.Add(1)
-			go sendTxAsync(curGasPrice)
+			go sendTxAsync(curTipCap, curFeeCap)
+
+		// A previously published attempt has fallen out of the
+		// mempool. Republish immediately at the same tip cap and fee
+		// cap, independent of the resubmission timeout.
+		case <-notInMempoolChan:
+			sawNotInMempool = true
+			log.Warn("Transaction missing from mempool, republishing",
+				"tip_cap", curTipCap, "fee_cap", curFeeCap)
+			wg.Add(1)
+			go sendTxAsync(curTipCap, curFeeCap)
+
+		// sendTx has determined that this attempt can never succeed,
+		// e.g. a Sender aborting after SafeAbortNonceTooLowCount.
+		case err := <-abortChan:
+			return nil, err
 
 		// The passed context has been canceled, i.e. in the event of a
-		// shutdown.
+		// shutdown or TxSendTimeout.
 		case <-ctxc.Done():
+			if sawNotInMempool {
+				return nil, ErrTxNotInMempool
+			}
 			return nil, ctxc.Err()
 
 		// The transaction has confirmed.
@@ -198,33 +391,118 @@ func (m *SimpleTxManager) Send(
 	}
 }
 
-// WaitMined blocks until the backend indicates confirmation of tx and returns
-// the tx receipt. Queries are made every queryInterval, regardless of whether
-// the backend returns an error. This method can be canceled using the passed
+// watchMempool waits out TxNotInMempoolTimeout and then checks whether the
+// backend still has visibility into tx. If the backend reports that tx is
+// neither pending nor known, notFound is signaled so that Send can
+// republish without waiting out a full ResubmissionTimeout.
+func (m *SimpleTxManager) watchMempool(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	tx *types.Transaction,
+	notFound chan<- struct{},
+) {
+	defer wg.Done()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(m.cfg.TxNotInMempoolTimeout):
+	}
+
+	callCtx, cancel := boundedCtx(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+
+	foundTx, isPending, err := m.backend.TransactionByHash(callCtx, tx.Hash())
+	if err == nil && (isPending || foundTx != nil) {
+		// The backend still knows the tx, either pending or mined.
+		return
+	}
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		// Treat an inconclusive lookup (e.g. a transient RPC error)
+		// as "still there" rather than aborting this attempt.
+		return
+	}
+
+	select {
+	case notFound <- struct{}{}:
+	default:
+	}
+}
+
+// WaitMined blocks until the backend indicates confirmation of the tx with
+// the given hash, at a depth of at least numConfirmations, and returns its
+// receipt. Queries are made every queryInterval, regardless of whether the
+// backend returns an error. This method can be canceled using the passed
 // context.
+//
+// Once a receipt has been observed, WaitMined keeps re-querying it on every
+// tick rather than returning immediately, so that a reorg which orphans the
+// tx's block can be detected before the confirmation depth is satisfied. If
+// the receipt disappears, or reappears under a different block hash, this
+// indicates the tx was reorged out and ErrTxReorged is returned.
 func WaitMined(
 	ctx context.Context,
 	backend ReceiptSource,
-	tx *types.Transaction,
+	txHash common.Hash,
 	queryInterval time.Duration,
+	numConfirmations uint64,
+	networkTimeout time.Duration,
 ) (*types.Receipt, error) {
 
 	queryTicker := time.NewTicker(queryInterval)
 	defer queryTicker.Stop()
 
-	txHash := tx.Hash()
+	var (
+		haveReceipt   bool
+		lastBlockHash common.Hash
+	)
 
 	for {
-		receipt, err := backend.TransactionReceipt(ctx, txHash)
-		if receipt != nil {
-			return receipt, nil
-		}
+		receiptCtx, cancel := boundedCtx(ctx, networkTimeout)
+		receipt, err := backend.TransactionReceipt(receiptCtx, txHash)
+		cancel()
+
+		switch {
+		case receipt == nil && err == nil && haveReceipt:
+			// The receipt we previously observed has disappeared
+			// before reaching our confirmation depth. A failed
+			// query is not treated as a disappearance.
+			return nil, ErrTxReorged
+
+		case receipt == nil:
+			if err != nil {
+				log.Trace("Receipt retrievel failed", "hash", txHash,
+					"err", err)
+			} else {
+				log.Trace("Transaction not yet mined", "hash", txHash)
+			}
 
-		if err != nil {
-			log.Trace("Receipt retrievel failed", "hash", txHash,
-				"err", err)
-		} else {
-			log.Trace("Transaction not yet mined", "hash", txHash)
+		case haveReceipt && receipt.BlockHash != lastBlockHash:
+			// The tx has been re-included under a different block
+			// than we last observed, i.e. its original block was
+			// reorged out.
+			return nil, ErrTxReorged
+
+		default:
+			haveReceipt = true
+			lastBlockHash = receipt.BlockHash
+
+			headCtx, cancel := boundedCtx(ctx, networkTimeout)
+			head, err := backend.HeaderByNumber(headCtx, nil)
+			cancel()
+			if err != nil {
+				log.Trace("Unable to fetch head to check confirmation depth",
+					"hash", txHash, "err", err)
+				break
+			}
+
+			confirmations := new(big.Int).Sub(head.Number, receipt.BlockNumber)
+			if confirmations.Cmp(new(big.Int).SetUint64(numConfirmations)) >= 0 {
+				return receipt, nil
+			}
+			log.Trace("Waiting for confirmations", "hash", txHash,
+				"confirmations", confirmations,
+				"required", numConfirmations)
 		}
 
 		select {
@@ -235,17 +513,123 @@ func WaitMined(
 	}
 }
 
-// NextGasPrice bumps the current gas price using an additive gasRetryIncrement,
-// clamping the resulting value to maxGasPrice.
+// initialGasPrice queries the backend for the latest base fee and suggested
+// tip cap and uses them to compute the tip cap and fee cap for a tx's very
+// first publication attempt. This mirrors the floor NextGasPrice applies to
+// resubmissions, so that only a genuinely idle fee market causes Send to
+// fall back to MinTipCap and MinBaseFee.
+//
+// The fee cap is set to twice the latest base fee, floored at twice
+// MinBaseFee. The tip cap is set to the backend's suggested tip cap, floored
+// at MinTipCap. Both values are clamped to FeeLimitThreshold.
+func (m *SimpleTxManager) initialGasPrice(
+	ctx context.Context,
+) (*big.Int, *big.Int, error) {
+
+	headCtx, cancel := boundedCtx(ctx, m.cfg.NetworkTimeout)
+	head, err := m.backend.HeaderByNumber(headCtx, nil)
+	cancel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	baseFee := head.BaseFee
+	if baseFee == nil {
+		baseFee = new(big.Int)
+	}
+	if baseFee.Cmp(m.cfg.MinBaseFee) < 0 {
+		baseFee = new(big.Int).Set(m.cfg.MinBaseFee)
+	}
+
+	tipCtx, cancel := boundedCtx(ctx, m.cfg.NetworkTimeout)
+	suggestedTipCap, err := m.backend.SuggestGasTipCap(tipCtx)
+	cancel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tipCap := suggestedTipCap
+	if tipCap.Cmp(m.cfg.MinTipCap) < 0 {
+		tipCap = new(big.Int).Set(m.cfg.MinTipCap)
+	}
+	if tipCap.Cmp(m.cfg.FeeLimitThreshold) > 0 {
+		tipCap = new(big.Int).Set(m.cfg.FeeLimitThreshold)
+	}
+
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(2))
+	if feeCap.Cmp(m.cfg.FeeLimitThreshold) > 0 {
+		feeCap = new(big.Int).Set(m.cfg.FeeLimitThreshold)
+	}
+
+	return tipCap, feeCap, nil
+}
+
+// NextGasPrice queries the backend for the latest base fee and computes the
+// next tip cap and fee cap to use for a resubmission, given the last tip cap
+// and fee cap that were used to publish a transaction.
 //
-// NOTE: This method does not mutate curGasPrice, but instead returns a copy.
-// This removes the possiblity of races occuring from goroutines sharing access
-// to the same underlying big.Int.
-func NextGasPrice(curGasPrice, gasRetryIncrement, maxGasPrice *big.Int) *big.Int {
-	nextGasPrice := new(big.Int).Set(curGasPrice)
-	nextGasPrice.Add(nextGasPrice, gasRetryIncrement)
-	if nextGasPrice.Cmp(maxGasPrice) == 1 {
-		nextGasPrice.Set(maxGasPrice)
-	}
-	return nextGasPrice
+// The fee cap is set to the greater of twice the latest base fee, or the last
+// fee cap bumped by the configured GasPricer. The tip cap is set to the
+// greater of the last tip cap bumped by the GasPricer, or MinTipCap. Both
+// values are clamped to FeeLimitThreshold.
+func (m *SimpleTxManager) NextGasPrice(
+	ctx context.Context, attempt int, lastTipCap, lastFeeCap *big.Int,
+) (*big.Int, *big.Int, error) {
+
+	headCtx, cancel := boundedCtx(ctx, m.cfg.NetworkTimeout)
+	head, err := m.backend.HeaderByNumber(headCtx, nil)
+	cancel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	baseFee := head.BaseFee
+	if baseFee == nil {
+		baseFee = new(big.Int)
+	}
+	if baseFee.Cmp(m.cfg.MinBaseFee) < 0 {
+		baseFee = new(big.Int).Set(m.cfg.MinBaseFee)
+	}
+
+	pricer := m.cfg.GasPricer
+	if pricer == nil {
+		pricer = &LinearBumper{Multiplier: m.cfg.FeeLimitMultiplier}
+	}
+
+	bumpedFeeCap, err := pricer.Next(ctx, attempt, lastFeeCap)
+	if err != nil {
+		return nil, nil, err
+	}
+	bumpedTipCap, err := pricer.Next(ctx, attempt, lastTipCap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doubledBaseFee := new(big.Int).Mul(baseFee, big.NewInt(2))
+
+	feeCap := doubledBaseFee
+	if bumpedFeeCap.Cmp(feeCap) > 0 {
+		feeCap = bumpedFeeCap
+	}
+	if feeCap.Cmp(m.cfg.FeeLimitThreshold) > 0 {
+		feeCap = new(big.Int).Set(m.cfg.FeeLimitThreshold)
+	}
+
+	tipCap := bumpedTipCap
+	if tipCap.Cmp(m.cfg.MinTipCap) < 0 {
+		tipCap = new(big.Int).Set(m.cfg.MinTipCap)
+	}
+	if tipCap.Cmp(m.cfg.FeeLimitThreshold) > 0 {
+		tipCap = new(big.Int).Set(m.cfg.FeeLimitThreshold)
+	}
+
+	return tipCap, feeCap, nil
+}
+
+// bumpByMultiplier multiplies value by multiplier, which is expressed as an
+// integer percentage (e.g. 150 means 1.5x), and returns the result. value is
+// not mutated.
+func bumpByMultiplier(value *big.Int, multiplier uint64) *big.Int {
+	bumped := new(big.Int).Mul(value, new(big.Int).SetUint64(multiplier))
+	return bumped.Div(bumped, big.NewInt(100))
 }
@@ -0,0 +1,344 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeReceiptSource is a minimal, in-memory ReceiptSource used to drive
+// SimpleTxManager in tests without a live backend.
+type fakeReceiptSource struct {
+	baseFee      *big.Int
+	suggestedTip *big.Int
+}
+
+func (f *fakeReceiptSource) TransactionReceipt(
+	_ context.Context, _ common.Hash,
+) (*types.Receipt, error) {
+	return nil, nil
+}
+
+func (f *fakeReceiptSource) HeaderByNumber(
+	_ context.Context, _ *big.Int,
+) (*types.Header, error) {
+	return &types.Header{BaseFee: f.baseFee}, nil
+}
+
+func (f *fakeReceiptSource) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return f.suggestedTip, nil
+}
+
+func (f *fakeReceiptSource) TransactionByHash(
+	_ context.Context, _ common.Hash,
+) (*types.Transaction, bool, error) {
+	return nil, false, nil
+}
+
+func testConfig() Config {
+	return Config{
+		MinTipCap:          big.NewInt(1),
+		MinBaseFee:         big.NewInt(10),
+		FeeLimitMultiplier: 150,
+		FeeLimitThreshold:  big.NewInt(1_000_000),
+	}
+}
+
+// TestInitialGasPriceUsesBackend verifies that the tip cap and fee cap for a
+// tx's first publication attempt are derived from the backend's reported
+// base fee and suggested tip cap, rather than unconditionally falling back
+// to MinTipCap and MinBaseFee.
+func TestInitialGasPriceUsesBackend(t *testing.T) {
+	backend := &fakeReceiptSource{
+		baseFee:      big.NewInt(100),
+		suggestedTip: big.NewInt(5),
+	}
+	mgr := NewSimpleTxManager(testConfig(), backend)
+
+	tipCap, feeCap, err := mgr.initialGasPrice(context.Background())
+	if err != nil {
+		t.Fatalf("initialGasPrice returned error: %v", err)
+	}
+	if tipCap.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("tipCap = %v, want 5", tipCap)
+	}
+	if feeCap.Cmp(big.NewInt(200)) != 0 {
+		t.Errorf("feeCap = %v, want 200 (2x base fee)", feeCap)
+	}
+}
+
+// TestInitialGasPriceFloorsAtMinimums verifies that a quiet fee market (low
+// base fee, low suggested tip) still floors out at MinBaseFee and MinTipCap.
+func TestInitialGasPriceFloorsAtMinimums(t *testing.T) {
+	backend := &fakeReceiptSource{
+		baseFee:      big.NewInt(1),
+		suggestedTip: big.NewInt(0),
+	}
+	cfg := testConfig()
+	mgr := NewSimpleTxManager(cfg, backend)
+
+	tipCap, feeCap, err := mgr.initialGasPrice(context.Background())
+	if err != nil {
+		t.Fatalf("initialGasPrice returned error: %v", err)
+	}
+	if tipCap.Cmp(cfg.MinTipCap) != 0 {
+		t.Errorf("tipCap = %v, want MinTipCap (%v)", tipCap, cfg.MinTipCap)
+	}
+	if feeCap.Cmp(new(big.Int).Mul(cfg.MinBaseFee, big.NewInt(2))) != 0 {
+		t.Errorf("feeCap = %v, want 2x MinBaseFee", feeCap)
+	}
+}
+
+// TestNextGasPriceBumpsTipCapAndFeeCap verifies that NextGasPrice applies
+// the configured GasPricer to both the tip cap and fee cap, and that a
+// bumped fee cap above twice the base fee is used as is.
+func TestNextGasPriceBumpsTipCapAndFeeCap(t *testing.T) {
+	backend := &fakeReceiptSource{
+		baseFee:      big.NewInt(10),
+		suggestedTip: big.NewInt(1),
+	}
+	cfg := testConfig()
+	cfg.FeeLimitMultiplier = 200
+	mgr := NewSimpleTxManager(cfg, backend)
+
+	tipCap, feeCap, err := mgr.NextGasPrice(
+		context.Background(), 1, big.NewInt(5), big.NewInt(100),
+	)
+	if err != nil {
+		t.Fatalf("NextGasPrice returned error: %v", err)
+	}
+	if want := big.NewInt(10); tipCap.Cmp(want) != 0 {
+		t.Errorf("tipCap = %v, want %v (2x bump)", tipCap, want)
+	}
+	if want := big.NewInt(200); feeCap.Cmp(want) != 0 {
+		t.Errorf("feeCap = %v, want %v (2x bump, above 2x base fee)", feeCap, want)
+	}
+}
+
+// TestNextGasPriceClampsFeeCapToThreshold verifies that a bump which would
+// exceed FeeLimitThreshold is clamped down to it instead.
+func TestNextGasPriceClampsFeeCapToThreshold(t *testing.T) {
+	backend := &fakeReceiptSource{
+		baseFee:      big.NewInt(10),
+		suggestedTip: big.NewInt(1),
+	}
+	cfg := testConfig()
+	cfg.FeeLimitMultiplier = 200
+	cfg.FeeLimitThreshold = big.NewInt(150)
+	mgr := NewSimpleTxManager(cfg, backend)
+
+	tipCap, feeCap, err := mgr.NextGasPrice(
+		context.Background(), 1, big.NewInt(5), big.NewInt(100),
+	)
+	if err != nil {
+		t.Fatalf("NextGasPrice returned error: %v", err)
+	}
+	if feeCap.Cmp(cfg.FeeLimitThreshold) != 0 {
+		t.Errorf("feeCap = %v, want FeeLimitThreshold (%v)", feeCap, cfg.FeeLimitThreshold)
+	}
+	if tipCap.Cmp(cfg.FeeLimitThreshold) > 0 {
+		t.Errorf("tipCap = %v, want at most FeeLimitThreshold (%v)", tipCap, cfg.FeeLimitThreshold)
+	}
+}
+
+// TestSendBumpsFeeOnResubmissionTimeout verifies that Send's event loop
+// actually exercises the ResubmissionTimeout branch: once the timeout
+// elapses without a confirmation, a new attempt is published at a strictly
+// higher fee cap, rather than looping on the same values forever.
+func TestSendBumpsFeeOnResubmissionTimeout(t *testing.T) {
+	backend := &fakeReceiptSource{
+		baseFee:      big.NewInt(1),
+		suggestedTip: big.NewInt(1),
+	}
+	cfg := testConfig()
+	cfg.ResubmissionTimeout = 10 * time.Millisecond
+
+	mgr := NewSimpleTxManager(cfg, backend)
+
+	var (
+		mu      sync.Mutex
+		feeCaps []*big.Int
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sendTx := func(
+		_ context.Context, _, feeCap *big.Int,
+	) (*types.Transaction, error) {
+		mu.Lock()
+		feeCaps = append(feeCaps, new(big.Int).Set(feeCap))
+		done := len(feeCaps) >= 3
+		mu.Unlock()
+
+		if done {
+			cancel()
+		}
+		return nil, errors.New("never confirms")
+	}
+
+	_, err := mgr.Send(ctx, sendTx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Send returned %v, want context.Canceled", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(feeCaps) < 3 {
+		t.Fatalf("sendTx called %d times, want at least 3", len(feeCaps))
+	}
+	for i := 1; i < len(feeCaps); i++ {
+		if feeCaps[i].Cmp(feeCaps[i-1]) <= 0 {
+			t.Errorf("feeCaps[%d] = %v, want strictly greater than feeCaps[%d] = %v",
+				i, feeCaps[i], i-1, feeCaps[i-1])
+		}
+	}
+}
+
+// reorgBackend is a ReceiptSource whose TransactionReceipt and HeaderByNumber
+// behavior is supplied by the test, used to script a reorg partway through a
+// WaitMined watch.
+type reorgBackend struct {
+	transactionReceipt func() (*types.Receipt, error)
+	headerByNumber     func() (*types.Header, error)
+	suggestedTip       *big.Int
+}
+
+func (b *reorgBackend) TransactionReceipt(
+	_ context.Context, _ common.Hash,
+) (*types.Receipt, error) {
+	return b.transactionReceipt()
+}
+
+func (b *reorgBackend) HeaderByNumber(
+	_ context.Context, _ *big.Int,
+) (*types.Header, error) {
+	return b.headerByNumber()
+}
+
+func (b *reorgBackend) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return b.suggestedTip, nil
+}
+
+func (b *reorgBackend) TransactionByHash(
+	_ context.Context, _ common.Hash,
+) (*types.Transaction, bool, error) {
+	return nil, false, nil
+}
+
+// TestSendResubmitsOnReorg exercises Send's reorg-recovery path, in which
+// sendTxAsync resubmits the same attempt by calling itself recursively. This
+// guards against a regression of a self-reference bug that previously made
+// the package fail to compile.
+func TestSendResubmitsOnReorg(t *testing.T) {
+	var (
+		mu           sync.Mutex
+		receiptCalls int
+		reorged      bool
+	)
+
+	backend := &reorgBackend{
+		suggestedTip: big.NewInt(1),
+		transactionReceipt: func() (*types.Receipt, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			receiptCalls++
+			switch receiptCalls {
+			case 1:
+				return &types.Receipt{
+					BlockHash:   common.HexToHash("0xaaa"),
+					BlockNumber: big.NewInt(1),
+				}, nil
+			case 2:
+				reorged = true
+				return nil, nil
+			default:
+				return &types.Receipt{
+					BlockHash:   common.HexToHash("0xbbb"),
+					BlockNumber: big.NewInt(2),
+				}, nil
+			}
+		},
+		headerByNumber: func() (*types.Header, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if reorged {
+				return &types.Header{Number: big.NewInt(3)}, nil
+			}
+			return &types.Header{Number: big.NewInt(1)}, nil
+		},
+	}
+
+	cfg := testConfig()
+	cfg.ResubmissionTimeout = time.Hour
+	cfg.ReceiptQueryInterval = 5 * time.Millisecond
+	cfg.NumConfirmations = 1
+
+	mgr := NewSimpleTxManager(cfg, backend)
+
+	fixedTx := types.NewTx(&types.LegacyTx{
+		Nonce:    0,
+		To:       &common.Address{},
+		Value:    big.NewInt(0),
+		Gas:      21000,
+		GasPrice: big.NewInt(1),
+	})
+
+	var sendCalls int32
+	sendTx := func(
+		_ context.Context, _, _ *big.Int,
+	) (*types.Transaction, error) {
+		atomic.AddInt32(&sendCalls, 1)
+		return fixedTx, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	receipt, err := mgr.Send(ctx, sendTx)
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if receipt.BlockHash != common.HexToHash("0xbbb") {
+		t.Errorf("receipt.BlockHash = %v, want 0xbbb", receipt.BlockHash)
+	}
+	if got := atomic.LoadInt32(&sendCalls); got != 2 {
+		t.Errorf("sendTx called %d times, want 2 (original publish + reorg resubmit)", got)
+	}
+}
+
+// TestSendAbortsOnTerminalSendTxError verifies that Send returns as soon as
+// sendTx reports a terminal error (ErrPublishTimeout), instead of leaving
+// the sendTxAsync goroutine to log and die silently while Send keeps
+// bumping fees and resubmitting the same exhausted attempt.
+func TestSendAbortsOnTerminalSendTxError(t *testing.T) {
+	backend := &fakeReceiptSource{
+		baseFee:      big.NewInt(1),
+		suggestedTip: big.NewInt(1),
+	}
+	cfg := testConfig()
+	cfg.ResubmissionTimeout = time.Hour
+
+	mgr := NewSimpleTxManager(cfg, backend)
+
+	sendTx := func(
+		_ context.Context, _, _ *big.Int,
+	) (*types.Transaction, error) {
+		return nil, ErrPublishTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err := mgr.Send(ctx, sendTx)
+	if !errors.Is(err, ErrPublishTimeout) {
+		t.Fatalf("Send returned %v, want ErrPublishTimeout", err)
+	}
+}